@@ -0,0 +1,76 @@
+// batch_test.go
+
+package suntime
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// batchLocations returns n locations spread over the inhabited latitudes and all longitudes.
+func batchLocations(n int) []Location {
+	r := rand.New(rand.NewSource(1))
+	locations := make([]Location, n)
+	for i := range locations {
+		locations[i] = Location{Latitude: r.Float64()*120 - 60, Longitude: r.Float64()*360 - 180}
+	}
+	return locations
+}
+
+func TestSunriseBatch(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+	locations := append(batchLocations(500), testLocation, Location{Latitude: 80})
+
+	sunrises := SunriseBatch(julianDay, locations)
+	sunsets := SunsetBatch(julianDay, locations)
+	for i, l := range locations {
+		for _, tt := range []struct {
+			name string
+			got  time.Time
+			want time.Time
+		}{
+			{"SunriseBatch", sunrises[i], Sunrise(julianDay, l.Longitude, l.Latitude)},
+			{"SunsetBatch", sunsets[i], Sunset(julianDay, l.Longitude, l.Latitude)},
+		} {
+			if diff := tt.got.Sub(tt.want); diff < -time.Second || diff > time.Second {
+				t.Errorf("%s() at %v = %v, want %v", tt.name, l, tt.got, tt.want)
+			}
+		}
+	}
+	if !sunrises[len(locations)-1].IsZero() {
+		t.Errorf("SunriseBatch() at 80° N = %v, want zero time", sunrises[len(locations)-1])
+	}
+}
+
+func TestSunriseBatchInvalidJulianDay(t *testing.T) {
+	locations := []Location{testLocation, {Latitude: 80}}
+	for _, times := range [][]time.Time{
+		SunriseBatch(-1e9, locations), SunsetBatch(-1e9, locations),
+	} {
+		for i, result := range times {
+			if !result.IsZero() {
+				t.Errorf("batch(-1e9) at %v = %v, want zero time", locations[i], result)
+			}
+		}
+	}
+}
+
+func BenchmarkSunriseBatch(b *testing.B) {
+	julianDay := ToJulianDay(testDate)
+	locations := batchLocations(1000)
+	for i := 0; i < b.N; i++ {
+		SunriseBatch(julianDay, locations)
+	}
+}
+
+func BenchmarkSunriseLoop(b *testing.B) {
+	julianDay := ToJulianDay(testDate)
+	locations := batchLocations(1000)
+	for i := 0; i < b.N; i++ {
+		times := make([]time.Time, len(locations))
+		for j, l := range locations {
+			times[j] = Sunrise(julianDay, l.Longitude, l.Latitude)
+		}
+	}
+}
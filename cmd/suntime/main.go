@@ -0,0 +1,100 @@
+// main.go
+
+// Command suntime prints the solar schedule for a place and date:
+//
+//	suntime --lat 38.85563 --lon -90.85866 --date 2025-01-07 --tz America/Chicago
+//
+// Coordinates may be signed decimal degrees or DMS such as 38° 51' 20.28" N.
+// Longitudes are positive east, as in ISO 6709 and most map software.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/heather7532/suntime"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "suntime:", err)
+		os.Exit(2)
+	}
+}
+
+// run parses the command line and writes the schedule table to w. Usage and flag
+// errors go to stderr, so w only ever holds the table.
+func run(args []string, w, stderr io.Writer) error {
+	fs := flag.NewFlagSet("suntime", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	lat := fs.String("lat", "", "latitude, decimal degrees (north positive) or DMS")
+	lon := fs.String("lon", "", "longitude, decimal degrees (east positive) or DMS")
+	date := fs.String("date", "", "date as YYYY-MM-DD (default today)")
+	tz := fs.String("tz", "UTC", "IANA time zone for the output, such as America/Chicago")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	latitude, err := parseCoordinate(*lat, true)
+	if err != nil {
+		return fmt.Errorf("--lat: %w", err)
+	}
+	longitude, err := parseCoordinate(*lon, false)
+	if err != nil {
+		return fmt.Errorf("--lon: %w", err)
+	}
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		return fmt.Errorf("--tz: %w", err)
+	}
+	day := time.Now().In(loc)
+	if *date != "" {
+		if day, err = time.ParseInLocation(time.DateOnly, *date, loc); err != nil {
+			return fmt.Errorf("--date: %w", err)
+		}
+	}
+
+	l := suntime.NewLocationEastPositive(latitude, longitude)
+	events, err := l.AllEvents(suntime.JulianDayForDate(day.Year(), int(day.Month()), day.Day()))
+	if err != nil {
+		return err
+	}
+	return printEvents(w, day, events.In(loc))
+}
+
+// parseCoordinate parses a signed decimal or DMS latitude or longitude.
+func parseCoordinate(input string, isLatitude bool) (float64, error) {
+	if input == "" {
+		return 0, fmt.Errorf("required")
+	}
+	return suntime.ParseLatLonComponent(input, isLatitude)
+}
+
+// printEvents writes the schedule as a two-line table in the layout of the USNO tables.
+func printEvents(w io.Writer, day time.Time, e suntime.Events) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Date\tAstro Dawn\tNaut Dawn\tDawn\tSunrise\tSolar Noon\tSunset\tDusk\t"+
+		"Naut Dusk\tAstro Dusk\tZone")
+	fmt.Fprint(tw, day.Format(time.DateOnly))
+	for _, t := range []time.Time{
+		e.AstronomicalDawn, e.NauticalDawn, e.CivilDawn, e.Sunrise, e.SolarNoon, e.Sunset,
+		e.CivilDusk, e.NauticalDusk, e.AstronomicalDusk,
+	} {
+		value := "-"
+		if !t.IsZero() {
+			value = t.Format(time.TimeOnly)
+		}
+		fmt.Fprint(tw, "\t"+value)
+	}
+	fmt.Fprintln(tw, "\t"+day.Location().String())
+	return tw.Flush()
+}
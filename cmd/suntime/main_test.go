@@ -0,0 +1,81 @@
+// main_test.go
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			"decimal",
+			[]string{"--lat", "38.85563244", "--lon", "-90.85866", "--date", "2025-01-07"},
+			[]string{"2025-01-07", "13:22:01", "18:09:38", "22:57:14", "UTC"},
+		},
+		{
+			"dms",
+			[]string{
+				"--lat", `38° 51' 20.28" N`, "--lon", `90° 51' 31.18" W`, "--date", "2025-01-07",
+				"--tz", "America/Chicago",
+			},
+			[]string{"07:22:01", "12:09:38", "16:57:14", "America/Chicago"},
+		},
+		{
+			"polar night",
+			[]string{"--lat", "80", "--lon", "0", "--date", "2025-01-07"},
+			[]string{"Sunrise", " - "},
+		},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		if err := run(tt.args, &out, io.Discard); err != nil {
+			t.Errorf("run(%s) error = %v", tt.name, err)
+			continue
+		}
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if len(lines) != 2 || !strings.HasPrefix(lines[0], "Date") {
+			t.Errorf("run(%s) output = %q, want a header and one row", tt.name, out.String())
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("run(%s) output = %q, want it to contain %q", tt.name, out.String(), want)
+			}
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"--lon", "-90.85"},
+		{"--lat", "91", "--lon", "0"},
+		{"--lat", "38.85", "--lon", "west"},
+		{"--lat", "38.85", "--lon", "-90.85", "--tz", "Nowhere/Special"},
+		{"--lat", "38.85", "--lon", "-90.85", "--date", "01/07/2025"},
+		{"--bogus"},
+	} {
+		var out strings.Builder
+		if err := run(args, &out, io.Discard); err == nil {
+			t.Errorf("run(%q) error = nil, want error", args)
+		}
+	}
+}
+
+func TestRunHelp(t *testing.T) {
+	var out, stderr strings.Builder
+	if err := run([]string{"--help"}, &out, &stderr); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("run(--help) error = %v, want %v", err, flag.ErrHelp)
+	}
+	if out.Len() != 0 || !strings.Contains(stderr.String(), "-lat") {
+		t.Errorf("run(--help) output = %q, stderr = %q, want usage on stderr only",
+			out.String(), stderr.String())
+	}
+}
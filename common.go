@@ -2,9 +2,211 @@
 
 package suntime
 
+import (
+	"strconv"
+	"time"
+)
+
 // DMS represents Degrees, Minutes, and Seconds
 type DMS struct {
 	Degrees int
 	Minutes int
 	Seconds float64
 }
+
+// String formats the DMS as 38° 51' 31.44", the form accepted by ParseSignedDMS.
+func (d DMS) String() string {
+	return strconv.Itoa(d.Degrees) + "° " + strconv.Itoa(d.Minutes) + "' " +
+		strconv.FormatFloat(d.Seconds, 'f', -1, 64) + `"`
+}
+
+// Format formats the DMS followed by a direction, as 38° 51' 31.44" N, the form
+// accepted by ParseDMS.
+func (d DMS) Format(direction string) string {
+	return d.String() + " " + direction
+}
+
+// Coordinate is a latitude or longitude in DMS together with its direction (N, S, E or W).
+type Coordinate struct {
+	DMS       DMS
+	Direction string
+}
+
+// Decimal converts the coordinate to signed decimal degrees.
+func (c Coordinate) Decimal() float64 {
+	return DmsToDecimal(c.DMS, c.Direction)
+}
+
+// String formats the coordinate as 38° 51' 31.44" N, the form accepted by ParseCoordinate.
+func (c Coordinate) String() string {
+	return c.DMS.Format(c.Direction)
+}
+
+// RefractionOptions describes the atmosphere at the observer for refraction at the horizon.
+// The zero value is the standard atmosphere (0°C, 1010 hPa) behind ZenithOfficial.
+type RefractionOptions struct {
+	TemperatureC float64
+	PressureHPa  float64 // 0 means the standard 1010 hPa
+	Model        RefractionModel
+
+	// SemidiameterArcmin is the sun's apparent radius; 0 means the mean 16'. See
+	// SolarSemidiameter for its value on a given day. RefractionNone ignores it.
+	SemidiameterArcmin float64
+}
+
+// RefractionModel selects how refraction at the horizon is computed.
+type RefractionModel int
+
+const (
+	// RefractionStandard is the fixed 34' of ZenithOfficial, scaled for the atmosphere
+	RefractionStandard RefractionModel = iota
+	// RefractionSaemundsson evaluates Saemundsson's formula at the sun's true altitude
+	RefractionSaemundsson
+	// RefractionNone is the geometric horizon, the sun's center at ZenithGeometric
+	RefractionNone
+)
+
+// Location is an observer position in decimal degrees. Longitude is positive west,
+// unlike ISO 6709; see NewLocationEastPositive.
+type Location struct {
+	Latitude  float64
+	Longitude float64 // positive west
+}
+
+// NewLocationEastPositive returns the Location for a latitude and an ISO 6709 longitude,
+// positive east, such as -90.85866 for Flint Hill, Missouri.
+func NewLocationEastPositive(latitude, longitude float64) Location {
+	return EastPositive.NewLocation(latitude, longitude)
+}
+
+// EastLongitude returns the location's longitude positive east, as in ISO 6709.
+func (l Location) EastLongitude() float64 {
+	return EastPositive.Longitude(l)
+}
+
+// LongitudeConvention is the sign convention of longitudes exchanged with the caller.
+// Build Locations with NewLocation and read them back with Longitude to work in one
+// convention throughout; every Location method then applies unchanged.
+type LongitudeConvention int
+
+const (
+	WestPositive LongitudeConvention = iota // the package's own convention
+	EastPositive                            // ISO 6709 and GIS
+)
+
+// NewLocation returns the Location for a latitude and a longitude in the convention.
+func (c LongitudeConvention) NewLocation(latitude, longitude float64) Location {
+	if c == EastPositive {
+		longitude = -longitude
+	}
+	return Location{Latitude: latitude, Longitude: longitude}
+}
+
+// Longitude returns the location's longitude in the convention.
+func (c LongitudeConvention) Longitude(l Location) float64 {
+	if c == EastPositive {
+		return -l.Longitude
+	}
+	return l.Longitude
+}
+
+// String returns "WestPositive" or "EastPositive".
+func (c LongitudeConvention) String() string {
+	switch c {
+	case WestPositive:
+		return "WestPositive"
+	case EastPositive:
+		return "EastPositive"
+	}
+	return "LongitudeConvention(" + strconv.Itoa(int(c)) + ")"
+}
+
+// Calendar selects how a calendar date is read when converting to a Julian day.
+type Calendar int
+
+const (
+	CalendarGregorian Calendar = iota // the proleptic Gregorian calendar of time.Time
+	CalendarJulian                    // the Julian calendar, in use before the 1582 reform
+)
+
+// DayWindow is the daylight interval of one day, from sunrise to sunset.
+type DayWindow struct {
+	Sunrise, Sunset time.Time
+}
+
+// Contains reports whether t falls within the window, including sunrise but not sunset.
+func (w DayWindow) Contains(t time.Time) bool {
+	return !t.Before(w.Sunrise) && t.Before(w.Sunset)
+}
+
+// Duration returns the length of the window.
+func (w DayWindow) Duration() time.Duration {
+	return w.Sunset.Sub(w.Sunrise)
+}
+
+// AnalemmaPoint is the sun's offset from mean noon on one day: the equation of time,
+// east-west, and the declination in degrees, north-south.
+type AnalemmaPoint struct {
+	EquationOfTime time.Duration
+	Declination    float64
+}
+
+// EventStatus reports whether a solar event occurs on a given day.
+type EventStatus int
+
+const (
+	EventOccurs        EventStatus = iota // the event occurs
+	EventSunAlwaysUp                      // the sun stays above the event's angle all day
+	EventSunAlwaysDown                    // the sun stays below the event's angle all day
+)
+
+// String returns "occurs", "sunAlwaysUp" or "sunAlwaysDown".
+func (s EventStatus) String() string {
+	switch s {
+	case EventOccurs:
+		return "occurs"
+	case EventSunAlwaysUp:
+		return "sunAlwaysUp"
+	case EventSunAlwaysDown:
+		return "sunAlwaysDown"
+	}
+	return "EventStatus(" + strconv.Itoa(int(s)) + ")"
+}
+
+// SunEvent is one crossing of a zenith angle together with how it was computed, for
+// logging and auditing. Time is the zero time unless Status is EventOccurs.
+type SunEvent struct {
+	Time      time.Time
+	Zenith    float64 // the zenith angle used, such as ZenithOfficial
+	IsSunrise bool
+	Status    EventStatus
+}
+
+// Events is the full solar schedule for one day, in chronological order.
+// Events that do not occur are left as the zero time and flagged in Status.
+type Events struct {
+	AstronomicalDawn time.Time
+	NauticalDawn     time.Time
+	CivilDawn        time.Time
+	Sunrise          time.Time
+	SolarNoon        time.Time
+	Sunset           time.Time
+	CivilDusk        time.Time
+	NauticalDusk     time.Time
+	AstronomicalDusk time.Time
+
+	Status EventsStatus
+}
+
+// EventsStatus holds the EventStatus of each field of Events.
+type EventsStatus struct {
+	AstronomicalDawn EventStatus `json:"astronomicalDawn"`
+	NauticalDawn     EventStatus `json:"nauticalDawn"`
+	CivilDawn        EventStatus `json:"civilDawn"`
+	Sunrise          EventStatus `json:"sunrise"`
+	SolarNoon        EventStatus `json:"solarNoon"`
+	Sunset           EventStatus `json:"sunset"`
+	CivilDusk        EventStatus `json:"civilDusk"`
+	NauticalDusk     EventStatus `json:"nauticalDusk"`
+	AstronomicalDusk EventStatus `json:"astronomicalDusk"`
+}
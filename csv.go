@@ -0,0 +1,34 @@
+// csv.go
+
+package suntime
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+)
+
+// WriteEventsCSV writes the schedules as CSV: a header row of the labels String uses,
+// then one row per schedule with each event in RFC 3339 in its own time zone. An event
+// that does not occur is written as an empty cell.
+func WriteEventsCSV(w io.Writer, events []Events) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(eventLabels[:]); err != nil {
+		return err
+	}
+
+	row := make([]string, len(eventLabels))
+	for _, e := range events {
+		for i, m := range e.moments() {
+			row[i] = ""
+			if *m.status == EventOccurs {
+				row[i] = m.t.Format(time.RFC3339)
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,47 @@
+// csv_test.go
+
+package suntime
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+func TestWriteEventsCSV(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	events, err := EventsRange(start, end, testLongitude, testLatitude)
+	if err != nil {
+		t.Fatalf("EventsRange() error = %v", err)
+	}
+	polar, _ := AllEvents(ToJulianDay(time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)), 0, 80)
+	events = append(events, polar)
+
+	var buf bytes.Buffer
+	if err := WriteEventsCSV(&buf, events); err != nil {
+		t.Fatalf("WriteEventsCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(records) != len(events)+1 {
+		t.Fatalf("WriteEventsCSV() wrote %d rows, want %d", len(records), len(events)+1)
+	}
+	if records[0][0] != "Astro Dawn" || records[0][8] != "Astro Dusk" {
+		t.Errorf("WriteEventsCSV() header = %v", records[0])
+	}
+
+	sunrise, err := time.Parse(time.RFC3339, records[7][3])
+	if want := events[6].Sunrise; err != nil || !sunrise.Equal(want) {
+		t.Errorf("WriteEventsCSV() sunrise = %q, want %v", records[7][3], want)
+	}
+
+	last := records[len(records)-1]
+	if last[3] != "" || last[4] == "" {
+		t.Errorf("WriteEventsCSV() polar row = %v, want an empty sunrise and a solar noon", last)
+	}
+}
@@ -0,0 +1,47 @@
+// errors.go
+
+package suntime
+
+import "fmt"
+
+// ErrorKind classifies the errors the package returns.
+type ErrorKind int
+
+const (
+	ErrorKindSunAlwaysUp      ErrorKind = iota + 1 // see ErrSunAlwaysUp
+	ErrorKindSunAlwaysDown                         // see ErrSunAlwaysDown
+	ErrorKindSunBelowHorizon                       // see ErrSunBelowHorizon
+	ErrorKindInvalidJulianDay                      // see ErrInvalidJulianDay
+	ErrorKindInvalidInput                          // see ErrInvalidInput
+	ErrorKindNotFound                              // see ErrNotFound
+)
+
+// SunError is the type of every error the package reports about the sun or its input.
+// Use errors.Is against the Err sentinels to test the kind, or errors.As to get the
+// SunError itself.
+type SunError struct {
+	Kind ErrorKind
+	Msg  string
+}
+
+// Error returns the message.
+func (e *SunError) Error() string {
+	return e.Msg
+}
+
+// Is reports whether target is a SunError of the same kind, so that errors.Is matches
+// an error against its sentinel whatever its message.
+func (e *SunError) Is(target error) bool {
+	t, ok := target.(*SunError)
+	return ok && t.Kind == e.Kind
+}
+
+// invalidInput returns a SunError of kind ErrorKindInvalidInput with a formatted message.
+func invalidInput(format string, args ...any) error {
+	return &SunError{Kind: ErrorKindInvalidInput, Msg: fmt.Sprintf(format, args...)}
+}
+
+// notFound returns a SunError of kind ErrorKindNotFound with a formatted message.
+func notFound(format string, args ...any) error {
+	return &SunError{Kind: ErrorKindNotFound, Msg: fmt.Sprintf(format, args...)}
+}
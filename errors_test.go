@@ -0,0 +1,58 @@
+// errors_test.go
+
+package suntime
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSunErrorIs(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+	june := JulianDayForDate(2025, 6, 21)
+	noon := time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("CST", -6*3600))
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+		kind ErrorKind
+	}{
+		{"polar night", eventErr(SunriseE(julianDay, 0, 80)), ErrSunAlwaysDown, ErrorKindSunAlwaysDown},
+		{"midnight sun", eventErr(SunsetE(julianDay, 0, -80)), ErrSunAlwaysUp, ErrorKindSunAlwaysUp},
+		{"julian day", eventErr(SunriseE(-1, 0, 0)), ErrInvalidJulianDay, ErrorKindInvalidJulianDay},
+		{"DMS", dmsErr(ParseDMS("38 N")), ErrInvalidInput, ErrorKindInvalidInput},
+		{"range", dmsErr(ParseDMS(`91° 0' 0" N`)), ErrInvalidInput, ErrorKindInvalidInput},
+		{"latitude", ValidateLatitude(91), ErrInvalidInput, ErrorKindInvalidInput},
+		{"sunrise at", eventErr(DateOfSunriseAt(2025, noon, testLocation)), ErrNotFound,
+			ErrorKindNotFound},
+		{"azimuth", eventErr(TimeOfAzimuth(june, 0, 10, 180)), ErrNotFound, ErrorKindNotFound},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: errors.Is(%v, %v) = false", tt.name, tt.err, tt.want)
+		}
+		var sunErr *SunError
+		if !errors.As(fmt.Errorf("wrapped: %w", tt.err), &sunErr) || sunErr.Kind != tt.kind {
+			t.Errorf("%s: errors.As(%v) = %v, want kind %v", tt.name, tt.err, sunErr, tt.kind)
+		}
+	}
+
+	if errors.Is(ErrSunAlwaysUp, ErrSunAlwaysDown) || errors.Is(ErrInvalidInput, ErrSunAlwaysUp) {
+		t.Error("errors.Is() matched sentinels of different kinds")
+	}
+	wrapped := fmt.Errorf("wrapped: %w", ErrSunAlwaysDown)
+	if status := eventStatus(wrapped); status != EventSunAlwaysDown {
+		t.Errorf("eventStatus(%v) = %v, want %v", wrapped, status, EventSunAlwaysDown)
+	}
+	if _, _, err := ParseDMS("38 N"); err.Error() != "invalid DMS format: 38 N" {
+		t.Errorf("ParseDMS() error = %q, want the original message", err)
+	}
+}
+
+// eventErr and dmsErr drop the values of a result, keeping its error
+func eventErr(_ time.Time, err error) error { return err }
+
+func dmsErr(_ DMS, _ string, err error) error { return err }
@@ -0,0 +1,441 @@
+// events.go
+
+package suntime
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AllEvents calculates the full solar schedule for a given Julian day, longitude, and latitude.
+func AllEvents(julianDay, longitude, latitude float64) (Events, error) {
+	return Location{Latitude: latitude, Longitude: longitude}.AllEvents(julianDay)
+}
+
+// AllEvents calculates the full solar schedule at the location. The transit, the
+// declination and their trigonometric terms are computed once and shared by all nine events.
+func (l Location) AllEvents(julianDay float64) (Events, error) {
+	if err := checkJulianDay(julianDay); err != nil {
+		return Events{}, err
+	}
+	Jtransit, delta := transit(JulianToUTC(julianDay), l.Longitude)
+	g := newCrossingGeometry(l.Latitude, delta)
+
+	var e Events
+	event := func(angle float64, isSunrise bool, t *time.Time, status *EventStatus) {
+		var err error
+		*t, err = g.crossing(Jtransit, angle, isSunrise)
+		*status = eventStatus(err)
+	}
+	event(ZenithAstronomical, true, &e.AstronomicalDawn, &e.Status.AstronomicalDawn)
+	event(ZenithNautical, true, &e.NauticalDawn, &e.Status.NauticalDawn)
+	event(ZenithCivil, true, &e.CivilDawn, &e.Status.CivilDawn)
+	event(ZenithOfficial, true, &e.Sunrise, &e.Status.Sunrise)
+	e.SolarNoon = roundEvent(FromJulianDay(Jtransit))
+	event(ZenithOfficial, false, &e.Sunset, &e.Status.Sunset)
+	event(ZenithCivil, false, &e.CivilDusk, &e.Status.CivilDusk)
+	event(ZenithNautical, false, &e.NauticalDusk, &e.Status.NauticalDusk)
+	event(ZenithAstronomical, false, &e.AstronomicalDusk, &e.Status.AstronomicalDusk)
+
+	return e, nil
+}
+
+// EventsRange calculates AllEvents for every calendar date from start to end inclusive.
+// The dates are taken from start and end in their own time zones.
+func EventsRange(start, end time.Time, longitude, latitude float64) ([]Events, error) {
+	return Location{Latitude: latitude, Longitude: longitude}.EventsRange(start, end)
+}
+
+// EventsRange calculates AllEvents at the location for every calendar date from start
+// to end inclusive.
+func (l Location) EventsRange(start, end time.Time) ([]Events, error) {
+	return l.EventsRangeContext(context.Background(), start, end)
+}
+
+// EventsRangeContext is like EventsRange but stops between days once ctx is done,
+// returning ctx.Err().
+func EventsRangeContext(
+	ctx context.Context, start, end time.Time, loc Location,
+) ([]Events, error) {
+	return loc.EventsRangeContext(ctx, start, end)
+}
+
+// EventsRangeContext is like EventsRange but stops between days once ctx is done.
+func (l Location) EventsRangeContext(
+	ctx context.Context, start, end time.Time,
+) ([]Events, error) {
+	first := JulianDayForDate(start.Year(), int(start.Month()), start.Day())
+	last := JulianDayForDate(end.Year(), int(end.Month()), end.Day())
+	if last < first {
+		return nil, invalidInput(
+			"invalid date range: %s is after %s", start.Format(time.DateOnly),
+			end.Format(time.DateOnly),
+		)
+	}
+
+	events := make([]Events, 0, int(last-first)+1)
+	for jd := first; jd <= last; jd++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		e, err := l.AllEvents(jd)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// MonthEvents calculates AllEvents at the location for every day of the month, for
+// rendering a calendar grid. The slice holds 28 to 31 schedules, one per day in order.
+func MonthEvents(year int, month time.Month, loc Location) ([]Events, error) {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return loc.EventsRange(first, first.AddDate(0, 1, -1))
+}
+
+// maxSearchDays bounds the forward search of NextEvent. A year and a
+// day always reaches the end of a polar night or day if one ends at all.
+const maxSearchDays = 367
+
+// NextSunrise returns the first sunrise strictly after the given instant, searching
+// forward day by day past polar nights. If no sunrise occurs within a year it returns
+// the error for the last day searched.
+func NextSunrise(after time.Time, longitude, latitude float64) (time.Time, error) {
+	return Location{Latitude: latitude, Longitude: longitude}.NextSunrise(after)
+}
+
+// NextSunset returns the first sunset strictly after the given instant, searching
+// forward day by day past polar days.
+func NextSunset(after time.Time, longitude, latitude float64) (time.Time, error) {
+	return Location{Latitude: latitude, Longitude: longitude}.NextSunset(after)
+}
+
+// NextSunrise returns the first sunrise at the location strictly after the given instant.
+func (l Location) NextSunrise(after time.Time) (time.Time, error) {
+	return l.NextEvent(after, EventKindSunrise)
+}
+
+// NextSunset returns the first sunset at the location strictly after the given instant.
+func (l Location) NextSunset(after time.Time) (time.Time, error) {
+	return l.NextEvent(after, EventKindSunset)
+}
+
+// EventKind selects one of the nine events of a schedule, for NextEvent.
+type EventKind int
+
+const (
+	EventKindAstronomicalDawn EventKind = iota
+	EventKindNauticalDawn
+	EventKindCivilDawn
+	EventKindSunrise
+	EventKindSolarNoon
+	EventKindSunset
+	EventKindCivilDusk
+	EventKindNauticalDusk
+	EventKindAstronomicalDusk
+)
+
+// eventKindCrossings are the zenith angle and morning flag of each EventKind; the entry
+// for EventKindSolarNoon is unused.
+var eventKindCrossings = [len(eventLabels)]struct {
+	angle     float64
+	isSunrise bool
+}{
+	{ZenithAstronomical, true}, {ZenithNautical, true}, {ZenithCivil, true},
+	{ZenithOfficial, true}, {}, {ZenithOfficial, false}, {ZenithCivil, false},
+	{ZenithNautical, false}, {ZenithAstronomical, false},
+}
+
+// String returns the label Events.String uses for the event, such as "Naut Dawn".
+func (k EventKind) String() string {
+	if k < 0 || int(k) >= len(eventLabels) {
+		return "EventKind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return eventLabels[k]
+}
+
+// NextEvent returns the first occurrence of the event at the location strictly after
+// the given instant, searching forward day by day like NextSunrise, so a scheduler can
+// wait on any event uniformly.
+func (l Location) NextEvent(after time.Time, event EventKind) (time.Time, error) {
+	if event < 0 || int(event) >= len(eventLabels) {
+		return time.Time{}, invalidInput("invalid event kind: %v", event)
+	}
+	if event == EventKindSolarNoon {
+		return l.nextEvent(after, l.SolarNoonE)
+	}
+	c := eventKindCrossings[event]
+	return l.nextEvent(after, func(jd float64) (time.Time, error) {
+		return l.SunAtAngle(jd, c.angle, c.isSunrise)
+	})
+}
+
+// nextEvent returns the first time given by event for a UTC date that is strictly
+// after the given instant. The search starts a day early because the event for a UTC
+// date can fall on the previous UTC day east of Greenwich.
+func (l Location) nextEvent(
+	after time.Time, event func(julianDay float64) (time.Time, error),
+) (time.Time, error) {
+	u := after.UTC()
+	first := JulianDayForDate(u.Year(), int(u.Month()), u.Day()) - 1
+
+	var err error
+	for jd := first; jd < first+maxSearchDays; jd++ {
+		var t time.Time
+		t, err = event(jd)
+		if err == nil && t.After(after) {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// EarliestSunrise returns the earliest sunrise of the year by time of day. Because of
+// the equation of time it falls about a week before the June solstice at mid-northern
+// latitudes. Days without a sunrise are skipped.
+func EarliestSunrise(year int, longitude, latitude float64) (time.Time, error) {
+	return Location{Latitude: latitude, Longitude: longitude}.EarliestSunrise(year)
+}
+
+// LatestSunset returns the latest sunset of the year by time of day, which falls about
+// a week after the June solstice at mid-northern latitudes.
+func LatestSunset(year int, longitude, latitude float64) (time.Time, error) {
+	return Location{Latitude: latitude, Longitude: longitude}.LatestSunset(year)
+}
+
+// EarliestSunrise returns the earliest sunrise of the year at the location.
+func (l Location) EarliestSunrise(year int) (time.Time, error) {
+	return l.extremeEvent(year, true)
+}
+
+// LatestSunset returns the latest sunset of the year at the location.
+func (l Location) LatestSunset(year int) (time.Time, error) {
+	return l.extremeEvent(year, false)
+}
+
+// extremeEvent scans the UTC dates of the year for the earliest sunrise or latest
+// sunset. Times of day are measured from each date's UTC midnight, so that an event
+// after midnight UTC still compares as late rather than early.
+func (l Location) extremeEvent(year int, isSunrise bool) (time.Time, error) {
+	first := JulianDayForDate(year, 1, 1)
+	last := JulianDayForDate(year+1, 1, 1)
+
+	var best time.Time
+	var bestOfDay time.Duration
+	var err error
+	for jd := first; jd < last; jd++ {
+		t, dayErr := l.SunAtAngle(jd, ZenithOfficial, isSunrise)
+		if dayErr != nil {
+			err = dayErr
+			continue
+		}
+		ofDay := t.Sub(FromJulianDay(jd))
+		if best.IsZero() || (isSunrise && ofDay < bestOfDay) || (!isSunrise && ofDay > bestOfDay) {
+			best, bestOfDay = t, ofDay
+		}
+	}
+	if best.IsZero() {
+		return time.Time{}, err
+	}
+	return best, nil
+}
+
+// sunriseAtTolerance is how far from the target time of day the sunrise found by
+// DateOfSunriseAt may be. Sunrise moves by at most a few minutes a day away from
+// the polar circles, so some day of a year that passes the target comes this close.
+const sunriseAtTolerance = 5 * time.Minute
+
+// DateOfSunriseAt returns the sunrise of the year whose wall-clock time of day, in
+// target's location, is closest to target's, such as the day sunrise is nearest 07:00
+// in America/Chicago. target's date is ignored. Sunrise usually passes a given time
+// twice a year; the nearer is returned, or the earlier on a tie. It returns ErrNotFound
+// if no sunrise comes within five minutes of the target.
+func DateOfSunriseAt(year int, target time.Time, loc Location) (time.Time, error) {
+	zone := target.Location()
+	want := timeOfDay(target)
+	first := JulianDayForDate(year, 1, 1)
+	last := JulianDayForDate(year+1, 1, 1)
+
+	var best time.Time
+	var bestDiff time.Duration
+	for jd := first; jd < last; jd++ {
+		t, err := loc.Sunrise(jd)
+		if err != nil {
+			continue
+		}
+		diff := (timeOfDay(t.In(zone)) - want).Abs()
+		if diff > 12*time.Hour {
+			diff = 24*time.Hour - diff
+		}
+		if best.IsZero() || diff < bestDiff {
+			best, bestDiff = t.In(zone), diff
+		}
+	}
+	if best.IsZero() || bestDiff > sunriseAtTolerance {
+		return time.Time{}, notFound("no sunrise in %d within %v of %s",
+			year, sunriseAtTolerance, target.Format(time.TimeOnly))
+	}
+	return best, nil
+}
+
+// timeOfDay returns the wall-clock time of day of t in its own location.
+func timeOfDay(t time.Time) time.Duration {
+	hour, minute, second := t.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + time.Duration(t.Nanosecond())
+}
+
+// MidnightSunPeriod returns the first and last dates of the midnight sun that begins in
+// the given year, when the sun does not set. A period under way on January 1 is
+// skipped, so in the southern hemisphere the one returned ends in the following year.
+// ok is false if no midnight sun begins in the year.
+func MidnightSunPeriod(year int, latitude float64) (start, end time.Time, ok bool) {
+	return polarPeriod(year, latitude, ErrSunAlwaysUp)
+}
+
+// PolarNightPeriod returns the first and last dates of the polar night that begins in
+// the given year, when the sun does not rise. In the northern hemisphere it ends in the
+// following year.
+func PolarNightPeriod(year int, latitude float64) (start, end time.Time, ok bool) {
+	return polarPeriod(year, latitude, ErrSunAlwaysDown)
+}
+
+// polarPeriod scans from the start of the year for the first run of days on which
+// sunrise fails with want, following it into the next year if needed.
+func polarPeriod(year int, latitude float64, want error) (start, end time.Time, ok bool) {
+	l := Location{Latitude: latitude}
+	first := JulianDayForDate(year, 1, 1)
+	last := JulianDayForDate(year+1, 1, 1)
+
+	inPeriod := func(jd float64) bool {
+		_, err := l.Sunrise(jd)
+		return errors.Is(err, want)
+	}
+
+	jd := first
+	for jd < last && inPeriod(jd) {
+		jd++
+	}
+	for jd < last && !inPeriod(jd) {
+		jd++
+	}
+	if jd >= last {
+		return time.Time{}, time.Time{}, false
+	}
+	startDay := jd
+	for jd < last+maxSearchDays && inPeriod(jd+1) {
+		jd++
+	}
+	return FromJulianDay(startDay), FromJulianDay(jd), true
+}
+
+// In returns a copy of the schedule with every event that occurs converted to loc.
+func (e Events) In(loc *time.Location) Events {
+	for _, m := range e.moments() {
+		if !m.t.IsZero() {
+			*m.t = m.t.In(loc)
+		}
+	}
+	return e
+}
+
+// eventLabels are the labels String and ParseEvents use, in chronological order.
+var eventLabels = [...]string{
+	"Astro Dawn", "Naut Dawn", "Dawn", "Sunrise", "Solar Noon", "Sunset", "Dusk",
+	"Naut Dusk", "Astro Dusk",
+}
+
+// moment is one event of a schedule and its status.
+type moment struct {
+	t      *time.Time
+	status *EventStatus
+}
+
+// moments returns the events of the schedule in chronological order.
+func (e *Events) moments() [len(eventLabels)]moment {
+	return [...]moment{
+		{&e.AstronomicalDawn, &e.Status.AstronomicalDawn},
+		{&e.NauticalDawn, &e.Status.NauticalDawn},
+		{&e.CivilDawn, &e.Status.CivilDawn},
+		{&e.Sunrise, &e.Status.Sunrise},
+		{&e.SolarNoon, &e.Status.SolarNoon},
+		{&e.Sunset, &e.Status.Sunset},
+		{&e.CivilDusk, &e.Status.CivilDusk},
+		{&e.NauticalDusk, &e.Status.NauticalDusk},
+		{&e.AstronomicalDusk, &e.Status.AstronomicalDusk},
+	}
+}
+
+// String formats the schedule on one line in chronological order with the labels of the
+// USNO table, as Astro Dawn 12:50:19, Naut Dawn 13:22:32, ... Events are shown in
+// their own time zones; use In to choose one. An event that does not occur is shown
+// by its status, as Sunrise sunAlwaysDown.
+func (e Events) String() string {
+	parts := make([]string, 0, len(eventLabels))
+	for i, m := range e.moments() {
+		value := m.status.String()
+		if *m.status == EventOccurs {
+			value = m.t.Format(time.TimeOnly)
+		}
+		parts = append(parts, eventLabels[i]+" "+value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseEvents parses a schedule written by String. The times are placed on date's
+// calendar day in its location, moving to the next day whenever a time is earlier
+// than the one before, so a schedule round-trips when its first event falls on date.
+func ParseEvents(input string, date time.Time) (Events, error) {
+	var e Events
+	parts := strings.Split(input, ", ")
+	if len(parts) != len(eventLabels) {
+		return Events{}, invalidInput("invalid events format: %s", input)
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	var previous time.Time
+	for i, m := range e.moments() {
+		value, ok := strings.CutPrefix(parts[i], eventLabels[i]+" ")
+		if !ok {
+			return Events{}, invalidInput("invalid events format: %s", parts[i])
+		}
+
+		switch value {
+		case EventSunAlwaysUp.String():
+			*m.status = EventSunAlwaysUp
+			continue
+		case EventSunAlwaysDown.String():
+			*m.status = EventSunAlwaysDown
+			continue
+		}
+		clock, err := time.Parse(time.TimeOnly, value)
+		if err != nil {
+			return Events{}, invalidInput("invalid events format: %s", parts[i])
+		}
+		t := time.Date(
+			day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0,
+			day.Location(),
+		)
+		if t.Before(previous) {
+			day = day.AddDate(0, 0, 1)
+			t = t.AddDate(0, 0, 1)
+		}
+		*m.t, previous = t, t
+	}
+	return e, nil
+}
+
+// eventStatus maps an error from the crossing calculation to an EventStatus.
+func eventStatus(err error) EventStatus {
+	switch {
+	case errors.Is(err, ErrSunAlwaysUp):
+		return EventSunAlwaysUp
+	case errors.Is(err, ErrSunAlwaysDown):
+		return EventSunAlwaysDown
+	}
+	return EventOccurs
+}
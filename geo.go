@@ -0,0 +1,32 @@
+// geo.go
+
+package suntime
+
+import "math"
+
+// earthRadiusKm is the mean radius of the WGS84 ellipsoid, (2a + b) / 3.
+const earthRadiusKm = 6371.0088
+
+// Distance calculates the great-circle distance between two locations in kilometers by
+// the haversine formula. Treating the Earth as a sphere of the WGS84 mean radius errs
+// by up to about 0.5% against the ellipsoid.
+func Distance(a, b Location) float64 {
+	lat1 := a.Latitude * DegreesToRadians
+	lat2 := b.Latitude * DegreesToRadians
+	dLat := lat2 - lat1
+	dLng := (b.Longitude - a.Longitude) * DegreesToRadians
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Antipode returns the point diametrically opposite the location through the Earth's
+// center, with the longitude wrapped to (-180, 180]. When the sun rises at a location
+// it sets, to within refraction, at the antipode.
+func Antipode(loc Location) Location {
+	longitude := loc.Longitude + 180
+	if longitude > 180 {
+		longitude -= 360
+	}
+	return Location{Latitude: -loc.Latitude, Longitude: longitude}
+}
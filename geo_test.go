@@ -0,0 +1,47 @@
+// geo_test.go
+
+package suntime
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDistance(t *testing.T) {
+	london := NewLocationEastPositive(51.5074, -0.1278)
+	paris := NewLocationEastPositive(48.8566, 2.3522)
+	if result := Distance(london, paris); math.Abs(result-343.6) > 1 {
+		t.Errorf("Distance(London, Paris) = %v, want about 343.6 km", result)
+	}
+
+	// Across the date line the short way round
+	fiji := NewLocationEastPositive(-17.7134, 178.0650)
+	samoa := NewLocationEastPositive(-13.7590, -172.1046)
+	if result := Distance(fiji, samoa); math.Abs(result-1145) > 10 {
+		t.Errorf("Distance(Fiji, Samoa) = %v, want about 1145 km", result)
+	}
+
+	if result := Distance(testLocation, testLocation); result != 0 {
+		t.Errorf("Distance(l, l) = %v, want 0", result)
+	}
+}
+
+func TestAntipode(t *testing.T) {
+	flintHill := NewLocationEastPositive(38.85, -90.85)
+	result := Antipode(flintHill)
+	want := NewLocationEastPositive(-38.85, 89.15)
+	if math.Abs(result.Latitude-want.Latitude) > 1e-9 ||
+		math.Abs(result.Longitude-want.Longitude) > 1e-9 {
+		t.Errorf("Antipode() = %v, want %v", result, want)
+	}
+	if back := Antipode(result); math.Abs(back.Longitude-flintHill.Longitude) > 1e-9 {
+		t.Errorf("Antipode(Antipode()) = %v, want %v", back, flintHill)
+	}
+
+	julianDay := ToJulianDay(testDate)
+	offset := flintHill.SolarNoon(julianDay).Sub(result.SolarNoon(julianDay))
+	if diff := offset - 12*time.Hour; diff.Abs() > time.Minute {
+		t.Errorf("solar noon is %v later than at the antipode, want about 12h", offset)
+	}
+}
@@ -7,4 +7,4 @@ require (
 	github.com/soniakeys/meeus/v3 v3.0.1
 )
 
-require github.com/soniakeys/unit v1.0.0 // indirect
+require github.com/soniakeys/unit v1.0.0
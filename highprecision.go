@@ -0,0 +1,50 @@
+// highprecision.go
+
+package suntime
+
+import (
+	"math"
+	"time"
+
+	"github.com/soniakeys/meeus/v3/coord"
+	pp "github.com/soniakeys/meeus/v3/planetposition"
+	"github.com/soniakeys/meeus/v3/sidereal"
+	"github.com/soniakeys/meeus/v3/solar"
+	"github.com/soniakeys/unit"
+)
+
+// SunPositionHighPrecision is like SunPosition but uses the solar theory of Meeus,
+// Astronomical Algorithms, chapter 25 with nutation and aberration, accurate to about
+// 10″ rather than the few arcminutes of the fast path. It costs several times as much.
+// ΔT is neglected; its 70 s moves the sun by under 3″.
+func SunPositionHighPrecision(
+	t time.Time, longitude, latitude float64,
+) (altitude, azimuth float64) {
+	jd := ToJulianDay(t)
+	α, δ := solar.ApparentEquatorial(jd)
+	return horizontal(jd, α, δ, longitude, latitude)
+}
+
+// SunPositionVSOP87 is like SunPositionHighPrecision but uses the full VSOP87 theory,
+// accurate to about an arcsecond. The Earth's VSOP87 series are not bundled; load them
+// with planetposition.LoadPlanet(planetposition.Earth) after downloading the VSOP87B
+// files and setting the VSOP87 environment variable to their directory.
+func SunPositionVSOP87(
+	t time.Time, longitude, latitude float64, earth *pp.V87Planet,
+) (altitude, azimuth float64) {
+	jd := ToJulianDay(t)
+	α, δ, _ := solar.ApparentEquatorialVSOP87(earth, jd)
+	return horizontal(jd, α, δ, longitude, latitude)
+}
+
+// horizontal converts apparent equatorial coordinates at jd to altitude and azimuth
+// from north in degrees. Longitude is positive west, as meeus expects.
+func horizontal(
+	jd float64, α unit.RA, δ unit.Angle, longitude, latitude float64,
+) (float64, float64) {
+	A, h := coord.EqToHz(
+		α, δ, unit.AngleFromDeg(latitude), unit.AngleFromDeg(longitude), sidereal.Apparent(jd),
+	)
+	// meeus measures azimuth westward from south
+	return h.Deg(), math.Mod(A.Deg()+540, 360)
+}
@@ -0,0 +1,67 @@
+// highprecision_test.go
+
+package suntime
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/soniakeys/meeus/v3/coord"
+	pp "github.com/soniakeys/meeus/v3/planetposition"
+	"github.com/soniakeys/meeus/v3/sidereal"
+	"github.com/soniakeys/unit"
+)
+
+// Meeus, Astronomical Algorithms, example 25.b: the sun's apparent position from
+// VSOP87 on 1992 October 13.0 is α = 13h13m30.749s, δ = -7°47'01.74".
+var (
+	ephemerisTime = time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC)
+	ephemerisRA   = unit.NewRA(13, 13, 30.749)
+	ephemerisDec  = unit.NewAngle('-', 7, 47, 1.74)
+)
+
+// ephemerisPosition returns the altitude and azimuth of the published position.
+func ephemerisPosition(longitude, latitude float64) (altitude, azimuth float64) {
+	jd := ToJulianDay(ephemerisTime)
+	A, h := coord.EqToHz(ephemerisRA, ephemerisDec, unit.AngleFromDeg(latitude),
+		unit.AngleFromDeg(longitude), sidereal.Apparent(jd))
+	return h.Deg(), math.Mod(A.Deg()+540, 360)
+}
+
+func TestSunPositionHighPrecision(t *testing.T) {
+	const arcsecond = 1.0 / 3600
+
+	for _, l := range []Location{
+		testLocation, {Latitude: 51.4769}, {Latitude: -33.87, Longitude: -151.21},
+	} {
+		altitude, azimuth := SunPositionHighPrecision(ephemerisTime, l.Longitude, l.Latitude)
+		wantAltitude, wantAzimuth := ephemerisPosition(l.Longitude, l.Latitude)
+		if math.Abs(altitude-wantAltitude) > 15*arcsecond ||
+			math.Abs(azimuth-wantAzimuth)*math.Cos(altitude*DegreesToRadians) > 15*arcsecond {
+			t.Errorf("SunPositionHighPrecision() at %v = %v, %v, want %v, %v within 15″", l,
+				altitude, azimuth, wantAltitude, wantAzimuth)
+		}
+
+		// The fast path agrees to a fraction of a degree.
+		fastAltitude, fastAzimuth := SunPosition(ephemerisTime, l.Longitude, l.Latitude)
+		if math.Abs(fastAltitude-altitude) > 0.5 || math.Abs(fastAzimuth-azimuth) > 0.5 {
+			t.Errorf("SunPosition() at %v = %v, %v, want near %v, %v", l, fastAltitude,
+				fastAzimuth, altitude, azimuth)
+		}
+	}
+}
+
+func TestSunPositionVSOP87(t *testing.T) {
+	earth, err := pp.LoadPlanet(pp.Earth)
+	if err != nil {
+		t.Skipf("VSOP87 data not available: %v", err)
+	}
+
+	altitude, azimuth := SunPositionVSOP87(ephemerisTime, testLongitude, testLatitude, earth)
+	wantAltitude, wantAzimuth := ephemerisPosition(testLongitude, testLatitude)
+	if math.Abs(altitude-wantAltitude) > 1.0/3600 || math.Abs(azimuth-wantAzimuth) > 1.0/3600 {
+		t.Errorf("SunPositionVSOP87() = %v, %v, want %v, %v within 1″", altitude, azimuth,
+			wantAltitude, wantAzimuth)
+	}
+}
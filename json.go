@@ -0,0 +1,142 @@
+// json.go
+
+package suntime
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// dmsJSON is the object form of DMS, {"degrees":38,"minutes":51,"seconds":31.44}.
+type dmsJSON struct {
+	Degrees int     `json:"degrees"`
+	Minutes int     `json:"minutes"`
+	Seconds float64 `json:"seconds"`
+}
+
+// coordinateJSON is the object form of Coordinate, the DMS fields plus "direction".
+type coordinateJSON struct {
+	dmsJSON
+	Direction string `json:"direction"`
+}
+
+// MarshalJSON encodes the DMS as {"degrees":38,"minutes":51,"seconds":31.44}.
+func (d DMS) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dmsJSON{Degrees: d.Degrees, Minutes: d.Minutes, Seconds: d.Seconds})
+}
+
+// UnmarshalJSON decodes either the object form written by MarshalJSON or the
+// string form written by String, such as "38° 51' 31.44\"".
+func (d *DMS) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		dms, direction, negative, err := parseDMS(s)
+		if err != nil {
+			return err
+		}
+		if direction != "" || negative {
+			return invalidInput("invalid DMS format: %s", s)
+		}
+		*d = dms
+		return nil
+	}
+
+	var v dmsJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*d = DMS{Degrees: v.Degrees, Minutes: v.Minutes, Seconds: v.Seconds}
+	return nil
+}
+
+// MarshalJSON encodes the coordinate as the DMS object with an added "direction" field.
+func (c Coordinate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(coordinateJSON{
+		dmsJSON:   dmsJSON{Degrees: c.DMS.Degrees, Minutes: c.DMS.Minutes, Seconds: c.DMS.Seconds},
+		Direction: c.Direction,
+	})
+}
+
+// UnmarshalJSON decodes either the object form written by MarshalJSON or the
+// string form accepted by ParseCoordinate, such as "38° 51' 31.44\" N".
+func (c *Coordinate) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		coord, err := ParseCoordinate(s)
+		if err != nil {
+			return err
+		}
+		*c = coord
+		return nil
+	}
+
+	var v coordinateJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	dms := DMS{Degrees: v.Degrees, Minutes: v.Minutes, Seconds: v.Seconds}
+	switch v.Direction {
+	case "N", "S", "E", "W":
+	default:
+		return invalidInput("invalid direction: %s", v.Direction)
+	}
+	if err := checkDMSRange(dms, v.Direction); err != nil {
+		return err
+	}
+	*c = Coordinate{DMS: dms, Direction: v.Direction}
+	return nil
+}
+
+// eventsJSON is the JSON form of Events, with events that do not occur left null.
+type eventsJSON struct {
+	AstronomicalDawn *string      `json:"astronomicalDawn"`
+	NauticalDawn     *string      `json:"nauticalDawn"`
+	CivilDawn        *string      `json:"civilDawn"`
+	Sunrise          *string      `json:"sunrise"`
+	SolarNoon        *string      `json:"solarNoon"`
+	Sunset           *string      `json:"sunset"`
+	CivilDusk        *string      `json:"civilDusk"`
+	NauticalDusk     *string      `json:"nauticalDusk"`
+	AstronomicalDusk *string      `json:"astronomicalDusk"`
+	Status           EventsStatus `json:"status"`
+}
+
+// MarshalJSON encodes the schedule with each event as an RFC 3339 string, or null if
+// it does not occur, and a "status" object giving the EventStatus of each event.
+func (e Events) MarshalJSON() ([]byte, error) {
+	format := func(t time.Time) *string {
+		if t.IsZero() {
+			return nil
+		}
+		s := t.Format(time.RFC3339)
+		return &s
+	}
+	return json.Marshal(eventsJSON{
+		AstronomicalDawn: format(e.AstronomicalDawn),
+		NauticalDawn:     format(e.NauticalDawn),
+		CivilDawn:        format(e.CivilDawn),
+		Sunrise:          format(e.Sunrise),
+		SolarNoon:        format(e.SolarNoon),
+		Sunset:           format(e.Sunset),
+		CivilDusk:        format(e.CivilDusk),
+		NauticalDusk:     format(e.NauticalDusk),
+		AstronomicalDusk: format(e.AstronomicalDusk),
+		Status:           e.Status,
+	})
+}
+
+// MarshalText encodes the status as its String form.
+func (s EventStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// isJSONString reports whether data holds a JSON string rather than an object.
+func isJSONString(data []byte) bool {
+	return len(data) > 0 && data[0] == '"'
+}
@@ -0,0 +1,118 @@
+// json_test.go
+
+package suntime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDMSJSON(t *testing.T) {
+	dms := DMS{Degrees: 38, Minutes: 51, Seconds: 31.44}
+
+	data, err := json.Marshal(dms)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != `{"degrees":38,"minutes":51,"seconds":31.44}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var result DMS
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Errorf("json.Unmarshal() error = %v", err)
+	}
+	if result != dms {
+		t.Errorf("json.Unmarshal() = %v, want %v", result, dms)
+	}
+
+	str, _ := json.Marshal(dms.String())
+	result = DMS{}
+	if err := json.Unmarshal(str, &result); err != nil {
+		t.Errorf("json.Unmarshal(%s) error = %v", str, err)
+	}
+	if result != dms {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", str, result, dms)
+	}
+
+	for _, input := range []string{`"38° 51' 31.44\" N"`, `"-38° 51' 31.44\""`, `"nope"`, `[]`} {
+		if err := json.Unmarshal([]byte(input), &result); err == nil {
+			t.Errorf("json.Unmarshal(%s) error = nil, want error", input)
+		}
+	}
+}
+
+func TestCoordinateJSON(t *testing.T) {
+	coord := Coordinate{DMS: DMS{Degrees: 90, Minutes: 51, Seconds: 31.18}, Direction: "W"}
+
+	data, err := json.Marshal(coord)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != `{"degrees":90,"minutes":51,"seconds":31.18,"direction":"W"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var result Coordinate
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Errorf("json.Unmarshal() error = %v", err)
+	}
+	if result != coord {
+		t.Errorf("json.Unmarshal() = %v, want %v", result, coord)
+	}
+
+	str, _ := json.Marshal(coord.String())
+	result = Coordinate{}
+	if err := json.Unmarshal(str, &result); err != nil {
+		t.Errorf("json.Unmarshal(%s) error = %v", str, err)
+	}
+	if result != coord {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", str, result, coord)
+	}
+
+	for _, input := range []string{
+		`{"degrees":38,"minutes":51,"seconds":31.44,"direction":"X"}`,
+		`{"degrees":91,"minutes":0,"seconds":0,"direction":"N"}`,
+		`"38° 51' 31.44\""`,
+	} {
+		if err := json.Unmarshal([]byte(input), &result); err == nil {
+			t.Errorf("json.Unmarshal(%s) error = nil, want error", input)
+		}
+	}
+}
+
+func TestEventsJSON(t *testing.T) {
+	// At 80° N in January the sun never rises, but astronomical twilight still occurs.
+	events, err := AllEvents(ToJulianDay(testDate), 0, 80)
+	if err != nil {
+		t.Fatalf("AllEvents() error = %v", err)
+	}
+	data, err := json.Marshal(events)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	for _, key := range []string{"civilDawn", "sunrise", "sunset", "civilDusk"} {
+		if result[key] != nil {
+			t.Errorf("json.Marshal() %s = %v, want null", key, result[key])
+		}
+	}
+	if result["solarNoon"] != events.SolarNoon.Format(time.RFC3339) {
+		t.Errorf("json.Marshal() solarNoon = %v, want %v", result["solarNoon"],
+			events.SolarNoon.Format(time.RFC3339))
+	}
+	if result["astronomicalDawn"] != events.AstronomicalDawn.Format(time.RFC3339) {
+		t.Errorf("json.Marshal() astronomicalDawn = %v, want %v", result["astronomicalDawn"],
+			events.AstronomicalDawn.Format(time.RFC3339))
+	}
+
+	status, _ := result["status"].(map[string]any)
+	if status["sunrise"] != "sunAlwaysDown" || status["solarNoon"] != "occurs" {
+		t.Errorf("json.Marshal() status = %v", status)
+	}
+}
@@ -0,0 +1,152 @@
+// location.go
+
+package suntime
+
+import (
+	"errors"
+	"time"
+)
+
+// SunAtAngle calculates the time the sun's center crosses the given zenith angle
+// at the location. See the package-level SunAtAngle.
+func (l Location) SunAtAngle(julianDay, zenithAngle float64, isSunrise bool) (time.Time, error) {
+	if err := checkJulianDay(julianDay); err != nil {
+		return time.Time{}, err
+	}
+	return calculateTime(JulianToUTC(julianDay), l.Longitude, l.Latitude, zenithAngle, isSunrise)
+}
+
+// SunEvent is like SunAtAngle but reports a crossing that does not occur in the result's
+// Status. It returns an error only for an out-of-range Julian day.
+func (l Location) SunEvent(julianDay, zenithAngle float64, isSunrise bool) (SunEvent, error) {
+	t, err := l.SunAtAngle(julianDay, zenithAngle, isSunrise)
+	if errors.Is(err, ErrInvalidJulianDay) {
+		return SunEvent{}, err
+	}
+	return SunEvent{
+		Time: t, Zenith: zenithAngle, IsSunrise: isSunrise, Status: eventStatus(err),
+	}, nil
+}
+
+// Validate returns an error if the latitude or longitude is out of range.
+func (l Location) Validate() error {
+	if err := ValidateLatitude(l.Latitude); err != nil {
+		return err
+	}
+	return ValidateLongitude(l.Longitude)
+}
+
+// Sunrise calculates the sunrise time at the location.
+func (l Location) Sunrise(julianDay float64) (time.Time, error) {
+	return l.SunAtAngle(julianDay, ZenithOfficial, true)
+}
+
+// Sunset calculates the sunset time at the location.
+func (l Location) Sunset(julianDay float64) (time.Time, error) {
+	return l.SunAtAngle(julianDay, ZenithOfficial, false)
+}
+
+// CivilTwilightSunrise calculates the civil twilight sunrise time at the location.
+func (l Location) CivilTwilightSunrise(julianDay float64) (time.Time, error) {
+	return l.SunAtAngle(julianDay, ZenithCivil, true)
+}
+
+// CivilTwilightSunset calculates the civil twilight sunset time at the location.
+func (l Location) CivilTwilightSunset(julianDay float64) (time.Time, error) {
+	return l.SunAtAngle(julianDay, ZenithCivil, false)
+}
+
+// NauticalTwilightSunrise calculates the nautical twilight sunrise time at the location.
+func (l Location) NauticalTwilightSunrise(julianDay float64) (time.Time, error) {
+	return l.SunAtAngle(julianDay, ZenithNautical, true)
+}
+
+// NauticalTwilightSunset calculates the nautical twilight sunset time at the location.
+func (l Location) NauticalTwilightSunset(julianDay float64) (time.Time, error) {
+	return l.SunAtAngle(julianDay, ZenithNautical, false)
+}
+
+// AstronomicalTwilightSunrise calculates the astronomical twilight sunrise time at the location.
+func (l Location) AstronomicalTwilightSunrise(julianDay float64) (time.Time, error) {
+	return l.SunAtAngle(julianDay, ZenithAstronomical, true)
+}
+
+// AstronomicalTwilightSunset calculates the astronomical twilight sunset time at the location.
+func (l Location) AstronomicalTwilightSunset(julianDay float64) (time.Time, error) {
+	return l.SunAtAngle(julianDay, ZenithAstronomical, false)
+}
+
+// SolarNoon calculates the time of solar transit at the location. It returns the zero
+// time for an out-of-range Julian day.
+func (l Location) SolarNoon(julianDay float64) time.Time {
+	t, _ := l.SolarNoonE(julianDay)
+	return t
+}
+
+// SolarNoonE is like SolarNoon but returns ErrInvalidJulianDay for an out-of-range
+// Julian day.
+func (l Location) SolarNoonE(julianDay float64) (time.Time, error) {
+	if err := checkJulianDay(julianDay); err != nil {
+		return time.Time{}, err
+	}
+	Jtransit, _ := transit(JulianToUTC(julianDay), l.Longitude)
+	return roundEvent(FromJulianDay(Jtransit)), nil
+}
+
+// DaylightWindow calculates the day's sunrise and sunset at the location as a DayWindow.
+func (l Location) DaylightWindow(julianDay float64) (DayWindow, error) {
+	sunrise, err := l.Sunrise(julianDay)
+	if err != nil {
+		return DayWindow{}, err
+	}
+	sunset, err := l.Sunset(julianDay)
+	if err != nil {
+		return DayWindow{}, err
+	}
+	return DayWindow{Sunrise: sunrise, Sunset: sunset}, nil
+}
+
+// SolarDayLength calculates the apparent solar day at the location, the time from
+// the solar transit on the given day to the next one.
+func (l Location) SolarDayLength(julianDay float64) time.Duration {
+	return l.SolarNoon(julianDay + 1).Sub(l.SolarNoon(julianDay))
+}
+
+// DayLength calculates the time between sunrise and sunset at the location.
+// On polar days it returns 24h with ErrSunAlwaysUp, on polar nights 0 with ErrSunAlwaysDown.
+func (l Location) DayLength(julianDay float64) (time.Duration, error) {
+	sunrise, err := l.Sunrise(julianDay)
+	if errors.Is(err, ErrSunAlwaysUp) {
+		return 24 * time.Hour, err
+	}
+	if err != nil {
+		return 0, err
+	}
+	sunset, err := l.Sunset(julianDay)
+	if err != nil {
+		return 0, err
+	}
+	return sunset.Sub(sunrise), nil
+}
+
+// SunriseAzimuth calculates the bearing of sunrise at the location, in degrees
+// clockwise from true north.
+func (l Location) SunriseAzimuth(julianDay float64) (float64, error) {
+	return riseSetAzimuth(julianDay, l.Longitude, l.Latitude, true)
+}
+
+// SunsetAzimuth calculates the bearing of sunset at the location, in degrees
+// clockwise from true north.
+func (l Location) SunsetAzimuth(julianDay float64) (float64, error) {
+	return riseSetAzimuth(julianDay, l.Longitude, l.Latitude, false)
+}
+
+// SunPosition calculates the sun's altitude and azimuth in degrees at the location.
+func (l Location) SunPosition(t time.Time) (altitude, azimuth float64) {
+	return SunPosition(t, l.Longitude, l.Latitude)
+}
+
+// IsDaytime reports whether the sun is up at the location at t.
+func (l Location) IsDaytime(t time.Time) bool {
+	return IsDaytime(t, l.Longitude, l.Latitude)
+}
@@ -0,0 +1,138 @@
+// location_test.go
+
+package suntime
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testLocation = Location{Latitude: testLatitude, Longitude: testLongitude}
+
+func TestLocationSunrise(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	result, err := testLocation.Sunrise(julianDay)
+	if err != nil {
+		t.Errorf("Location.Sunrise() error = %v", err)
+	}
+	expected := Sunrise(julianDay, testLongitude, testLatitude)
+	if !result.Equal(expected) {
+		t.Errorf("Location.Sunrise() = %v, want %v", result, expected)
+	}
+}
+
+func TestLocationEvents(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	tests := []struct {
+		name     string
+		method   func(float64) (time.Time, error)
+		function func(float64, float64, float64) time.Time
+	}{
+		{"Sunset", testLocation.Sunset, Sunset},
+		{"CivilTwilightSunrise", testLocation.CivilTwilightSunrise, CivilTwilightSunrise},
+		{"CivilTwilightSunset", testLocation.CivilTwilightSunset, CivilTwilightSunset},
+		{"NauticalTwilightSunrise", testLocation.NauticalTwilightSunrise, NauticalTwilightSunrise},
+		{"NauticalTwilightSunset", testLocation.NauticalTwilightSunset, NauticalTwilightSunset},
+		{
+			"AstronomicalTwilightSunrise", testLocation.AstronomicalTwilightSunrise,
+			AstronomicalTwilightSunrise,
+		},
+		{
+			"AstronomicalTwilightSunset", testLocation.AstronomicalTwilightSunset,
+			AstronomicalTwilightSunset,
+		},
+	}
+	for _, tt := range tests {
+		result, err := tt.method(julianDay)
+		if err != nil {
+			t.Errorf("Location.%s() error = %v", tt.name, err)
+		}
+		expected := tt.function(julianDay, testLongitude, testLatitude)
+		if !result.Equal(expected) {
+			t.Errorf("Location.%s() = %v, want %v", tt.name, result, expected)
+		}
+	}
+}
+
+func TestLocationSolarNoon(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	result := testLocation.SolarNoon(julianDay)
+	expected := SolarNoon(julianDay, testLongitude, testLatitude)
+	if !result.Equal(expected) {
+		t.Errorf("Location.SolarNoon() = %v, want %v", result, expected)
+	}
+}
+
+func TestNewLocationEastPositive(t *testing.T) {
+	// Flint Hill, Missouri is at 90.85866° W, -90.85866 in the usual GIS convention.
+	l := NewLocationEastPositive(testLatitude, -testLongitude)
+	if l != testLocation {
+		t.Errorf("NewLocationEastPositive() = %v, want %v", l, testLocation)
+	}
+	if l.EastLongitude() != -testLongitude {
+		t.Errorf("Location.EastLongitude() = %v, want %v", l.EastLongitude(), -testLongitude)
+	}
+
+	result, err := l.Sunrise(ToJulianDay(testDate))
+	if err != nil {
+		t.Errorf("Location.Sunrise() error = %v", err)
+	}
+	expected := time.Date(2025, 1, 7, 13, 22, 1, 0, time.UTC)
+	if !result.Equal(expected) {
+		t.Errorf("Location.Sunrise() = %v, want %v", result, expected)
+	}
+}
+
+func TestLocationSunEvent(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	// Polar night at 80° N in January
+	polar := Location{Latitude: 80}
+	result, err := polar.SunEvent(julianDay, ZenithOfficial, true)
+	if err != nil {
+		t.Fatalf("SunEvent() error = %v", err)
+	}
+	want := SunEvent{Zenith: ZenithOfficial, IsSunrise: true, Status: EventSunAlwaysDown}
+	if result != want {
+		t.Errorf("SunEvent() = %+v, want %+v", result, want)
+	}
+
+	result, err = SunsetEvent(julianDay, testLongitude, testLatitude)
+	if err != nil || result.Status != EventOccurs || result.IsSunrise ||
+		!result.Time.Equal(Sunset(julianDay, testLongitude, testLatitude)) {
+		t.Errorf("SunsetEvent() = %+v, %v", result, err)
+	}
+
+	if _, err := polar.SunEvent(-1, ZenithOfficial, true); !errors.Is(err, ErrInvalidJulianDay) {
+		t.Errorf("SunEvent(-1) error = %v, want ErrInvalidJulianDay", err)
+	}
+}
+
+func TestLongitudeConvention(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+	west := WestPositive.NewLocation(testLatitude, testLongitude)
+	east := EastPositive.NewLocation(testLatitude, -testLongitude)
+	if west != testLocation || east != testLocation {
+		t.Fatalf("NewLocation() = %v, %v, want %v", west, east, testLocation)
+	}
+
+	westSunrise, err := west.Sunrise(julianDay)
+	if err != nil {
+		t.Fatalf("Sunrise() error = %v", err)
+	}
+	eastSunrise, err := east.Sunrise(julianDay)
+	if err != nil || !eastSunrise.Equal(westSunrise) {
+		t.Errorf("Sunrise() east = %v, %v, want %v", eastSunrise, err, westSunrise)
+	}
+
+	if result := EastPositive.Longitude(west); result != -testLongitude {
+		t.Errorf("EastPositive.Longitude() = %v, want %v", result, -testLongitude)
+	}
+	if result := WestPositive.Longitude(east); result != testLongitude {
+		t.Errorf("WestPositive.Longitude() = %v, want %v", result, testLongitude)
+	}
+}
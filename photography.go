@@ -0,0 +1,89 @@
+// photography.go
+
+package suntime
+
+import "time"
+
+// Zenith distances bounding the golden and blue hours.
+const (
+	zenithGoldenHigh = 84.0 // 6° above the horizon
+	zenithBlueHigh   = 94.0 // 4° below the horizon
+)
+
+// GoldenHourMorning calculates the morning golden hour, from civil dawn (sun at -6°)
+// until the sun reaches 6° above the horizon.
+func GoldenHourMorning(julianDay, longitude, latitude float64) (start, end time.Time, err error) {
+	return angleWindow(julianDay, longitude, latitude, ZenithCivil, zenithGoldenHigh, true)
+}
+
+// GoldenHourEvening calculates the evening golden hour, from the sun dropping below 6°
+// above the horizon until civil dusk (sun at -6°).
+func GoldenHourEvening(julianDay, longitude, latitude float64) (start, end time.Time, err error) {
+	return angleWindow(julianDay, longitude, latitude, zenithGoldenHigh, ZenithCivil, false)
+}
+
+// BlueHourMorning calculates the morning blue hour, while the sun climbs from 6° to 4°
+// below the horizon.
+func BlueHourMorning(julianDay, longitude, latitude float64) (start, end time.Time, err error) {
+	return angleWindow(julianDay, longitude, latitude, ZenithCivil, zenithBlueHigh, true)
+}
+
+// BlueHourEvening calculates the evening blue hour, while the sun sinks from 4° to 6°
+// below the horizon.
+func BlueHourEvening(julianDay, longitude, latitude float64) (start, end time.Time, err error) {
+	return angleWindow(julianDay, longitude, latitude, zenithBlueHigh, ZenithCivil, false)
+}
+
+// angleWindow returns the crossings of two zenith angles on the same side of solar noon.
+// Either crossing failing to occur is reported with the sentinel error from SunAtAngle.
+func angleWindow(
+	julianDay, longitude, latitude, startZenith, endZenith float64, isSunrise bool,
+) (start, end time.Time, err error) {
+	start, err = SunAtAngle(julianDay, longitude, latitude, startZenith, isSunrise)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err = SunAtAngle(julianDay, longitude, latitude, endZenith, isSunrise)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// PhotoWindow is one golden or blue hour, from Start to End.
+type PhotoWindow struct {
+	Start, End time.Time
+}
+
+// PhotoWindows holds the day's golden and blue hours in chronological order. The
+// morning windows both open at civil dawn and the evening windows both close at civil
+// dusk, so each pair overlaps.
+type PhotoWindows struct {
+	BlueMorning   PhotoWindow
+	GoldenMorning PhotoWindow
+	GoldenEvening PhotoWindow
+	BlueEvening   PhotoWindow
+}
+
+// PhotographyWindows calculates the morning and evening blue and golden hours for a day.
+// It fails with the first sentinel error from the individual window functions.
+func PhotographyWindows(julianDay, longitude, latitude float64) (PhotoWindows, error) {
+	var w PhotoWindows
+	windows := []struct {
+		window *PhotoWindow
+		fn     func(julianDay, longitude, latitude float64) (start, end time.Time, err error)
+	}{
+		{&w.BlueMorning, BlueHourMorning},
+		{&w.GoldenMorning, GoldenHourMorning},
+		{&w.GoldenEvening, GoldenHourEvening},
+		{&w.BlueEvening, BlueHourEvening},
+	}
+	for _, win := range windows {
+		start, end, err := win.fn(julianDay, longitude, latitude)
+		if err != nil {
+			return PhotoWindows{}, err
+		}
+		*win.window = PhotoWindow{Start: start, End: end}
+	}
+	return w, nil
+}
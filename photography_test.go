@@ -0,0 +1,131 @@
+// photography_test.go
+
+package suntime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGoldenHourMorning(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	start, end, err := GoldenHourMorning(julianDay, testLongitude, testLatitude)
+	if err != nil {
+		t.Errorf("GoldenHourMorning() error = %v", err)
+	}
+	civilDawn := CivilTwilightSunrise(julianDay, testLongitude, testLatitude)
+	if !start.Equal(civilDawn) {
+		t.Errorf("GoldenHourMorning() start = %v, want %v", start, civilDawn)
+	}
+	sunrise := Sunrise(julianDay, testLongitude, testLatitude)
+	if !end.After(sunrise) {
+		t.Errorf("GoldenHourMorning() end = %v, want after %v", end, sunrise)
+	}
+}
+
+func TestGoldenHourEvening(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	start, end, err := GoldenHourEvening(julianDay, testLongitude, testLatitude)
+	if err != nil {
+		t.Errorf("GoldenHourEvening() error = %v", err)
+	}
+	sunset := Sunset(julianDay, testLongitude, testLatitude)
+	civilDusk := CivilTwilightSunset(julianDay, testLongitude, testLatitude)
+	if !start.Before(sunset) || !end.Equal(civilDusk) {
+		t.Errorf(
+			"GoldenHourEvening() = %v, %v, want start before %v and end at %v", start, end,
+			sunset, civilDusk,
+		)
+	}
+}
+
+func TestGoldenHourSunTooLow(t *testing.T) {
+	// At 65°N on the December solstice the sun rises but peaks below 6°
+	julianDay := ToJulianDay(time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC))
+
+	if _, err := SunriseE(julianDay, 0, 65.0); err != nil {
+		t.Errorf("SunriseE() error = %v", err)
+	}
+	_, _, err := GoldenHourMorning(julianDay, 0, 65.0)
+	if err != ErrSunAlwaysDown {
+		t.Errorf("GoldenHourMorning() error = %v, want %v", err, ErrSunAlwaysDown)
+	}
+}
+
+func TestBlueHourMorning(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	start, end, err := BlueHourMorning(julianDay, testLongitude, testLatitude)
+	if err != nil {
+		t.Errorf("BlueHourMorning() error = %v", err)
+	}
+	sunrise := Sunrise(julianDay, testLongitude, testLatitude)
+	if !start.Before(end) || !end.Before(sunrise) {
+		t.Errorf("BlueHourMorning() = %v, %v, want both before %v", start, end, sunrise)
+	}
+}
+
+func TestBlueHourEvening(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	start, end, err := BlueHourEvening(julianDay, testLongitude, testLatitude)
+	if err != nil {
+		t.Errorf("BlueHourEvening() error = %v", err)
+	}
+	sunset := Sunset(julianDay, testLongitude, testLatitude)
+	civilDusk := CivilTwilightSunset(julianDay, testLongitude, testLatitude)
+	if !start.After(sunset) || !end.After(start) || end.After(civilDusk) {
+		t.Errorf(
+			"BlueHourEvening() = %v, %v, want between %v and %v", start, end, sunset, civilDusk,
+		)
+	}
+}
+
+func TestBlueHourPolarNight(t *testing.T) {
+	julianDay := ToJulianDay(time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC))
+
+	_, _, err := BlueHourEvening(julianDay, -15.6, 85.0)
+	if err != ErrSunAlwaysDown {
+		t.Errorf("BlueHourEvening() error = %v, want %v", err, ErrSunAlwaysDown)
+	}
+}
+
+func TestPhotographyWindows(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	w, err := PhotographyWindows(julianDay, testLongitude, testLatitude)
+	if err != nil {
+		t.Fatalf("PhotographyWindows() error = %v", err)
+	}
+	order := []PhotoWindow{w.BlueMorning, w.GoldenMorning, w.GoldenEvening, w.BlueEvening}
+	for i, win := range order {
+		if !win.Start.Before(win.End) {
+			t.Errorf("PhotographyWindows() window %d = %v to %v, want start before end", i,
+				win.Start, win.End)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := order[i-1]
+		if win.Start.Before(prev.Start) || win.End.Before(prev.End) {
+			t.Errorf("PhotographyWindows() window %d = %v to %v, want no earlier than %v to %v", i,
+				win.Start, win.End, prev.Start, prev.End)
+		}
+	}
+
+	morning, _, _ := BlueHourMorning(julianDay, testLongitude, testLatitude)
+	if !w.BlueMorning.Start.Equal(morning) {
+		t.Errorf("PhotographyWindows() BlueMorning.Start = %v, want %v", w.BlueMorning.Start,
+			morning)
+	}
+}
+
+func TestPhotographyWindowsSunTooLow(t *testing.T) {
+	julianDay := ToJulianDay(time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC))
+
+	if _, err := PhotographyWindows(julianDay, 0, 65.0); err != ErrSunAlwaysDown {
+		t.Errorf("PhotographyWindows() error = %v, want %v", err, ErrSunAlwaysDown)
+	}
+}
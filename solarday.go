@@ -0,0 +1,120 @@
+// solarday.go
+
+package suntime
+
+import "time"
+
+// SolarDay holds the date-dependent part of the event calculations for one UTC date,
+// so that many events and locations can share it. The solar coordinates are evaluated
+// three times, at the start, middle and end of the range of mean solar noons around
+// the world, and interpolated for each longitude. Results agree with the Location
+// methods to within a second. All nine daily events from one SolarDay take about 60%
+// of the time of the nine Location methods (see BenchmarkSolarDay).
+type SolarDay struct {
+	curve     solarCurve
+	julianDay float64
+}
+
+// NewSolarDay prepares the solar coordinates for the UTC date selected by julianDay,
+// as for Sunrise.
+func NewSolarDay(julianDay float64) SolarDay {
+	return SolarDay{
+		curve: newSolarCurve(meanNoon(JulianToUTC(julianDay), 0)), julianDay: julianDay,
+	}
+}
+
+// transit returns the Julian date of solar transit and the declination (in radians)
+// at that transit at the given longitude, positive west, as the package-level transit.
+func (s SolarDay) transit(longitude float64) (float64, float64) {
+	Jstar := s.curve.noon + longitude/360.0
+	_, offset := s.curve.at(Jstar)
+	delta, _ := s.curve.at(Jstar + offset)
+	return J2000 + Jstar + offset, delta
+}
+
+// SunAtAngle calculates the time the sun's center crosses the given zenith angle at the
+// location. See the package-level SunAtAngle.
+func (s SolarDay) SunAtAngle(l Location, zenithAngle float64, isSunrise bool) (time.Time, error) {
+	if err := checkJulianDay(s.julianDay); err != nil {
+		return time.Time{}, err
+	}
+	Jtransit, delta := s.transit(l.Longitude)
+	return crossing(Jtransit, delta, l.Latitude, zenithAngle, isSunrise)
+}
+
+// Sunrise calculates the sunrise time at the location.
+func (s SolarDay) Sunrise(l Location) (time.Time, error) {
+	return s.SunAtAngle(l, ZenithOfficial, true)
+}
+
+// Sunset calculates the sunset time at the location.
+func (s SolarDay) Sunset(l Location) (time.Time, error) {
+	return s.SunAtAngle(l, ZenithOfficial, false)
+}
+
+// CivilTwilightSunrise calculates the civil twilight sunrise time at the location.
+func (s SolarDay) CivilTwilightSunrise(l Location) (time.Time, error) {
+	return s.SunAtAngle(l, ZenithCivil, true)
+}
+
+// CivilTwilightSunset calculates the civil twilight sunset time at the location.
+func (s SolarDay) CivilTwilightSunset(l Location) (time.Time, error) {
+	return s.SunAtAngle(l, ZenithCivil, false)
+}
+
+// NauticalTwilightSunrise calculates the nautical twilight sunrise time at the location.
+func (s SolarDay) NauticalTwilightSunrise(l Location) (time.Time, error) {
+	return s.SunAtAngle(l, ZenithNautical, true)
+}
+
+// NauticalTwilightSunset calculates the nautical twilight sunset time at the location.
+func (s SolarDay) NauticalTwilightSunset(l Location) (time.Time, error) {
+	return s.SunAtAngle(l, ZenithNautical, false)
+}
+
+// AstronomicalTwilightSunrise calculates the astronomical twilight sunrise time at the location.
+func (s SolarDay) AstronomicalTwilightSunrise(l Location) (time.Time, error) {
+	return s.SunAtAngle(l, ZenithAstronomical, true)
+}
+
+// AstronomicalTwilightSunset calculates the astronomical twilight sunset time at the location.
+func (s SolarDay) AstronomicalTwilightSunset(l Location) (time.Time, error) {
+	return s.SunAtAngle(l, ZenithAstronomical, false)
+}
+
+// SolarNoon calculates the time of solar transit at the location. It returns the zero
+// time for an out-of-range Julian day.
+func (s SolarDay) SolarNoon(l Location) time.Time {
+	if checkJulianDay(s.julianDay) != nil {
+		return time.Time{}
+	}
+	Jtransit, _ := s.transit(l.Longitude)
+	return roundEvent(FromJulianDay(Jtransit))
+}
+
+// solarCurve interpolates the declination and transit offset from solarCoordinates
+// over the day centered on noon, the mean solar noon at Greenwich in days since J2000.0.
+type solarCurve struct {
+	noon          float64
+	delta, offset [3]float64 // at noon - 0.5, noon and noon + 0.5
+}
+
+func newSolarCurve(noon float64) solarCurve {
+	c := solarCurve{noon: noon}
+	for i := range c.delta {
+		c.delta[i], c.offset[i] = solarCoordinates(noon + float64(i-1)/2)
+	}
+	return c
+}
+
+// at returns the declination (in radians) and transit offset at d by quadratic
+// interpolation; d should be within half a day of the curve's noon.
+func (c solarCurve) at(d float64) (float64, float64) {
+	x := 2 * (d - c.noon) // -1 at the start of the range, 1 at the end
+	return interpolate3(c.delta, x), interpolate3(c.offset, x)
+}
+
+// interpolate3 evaluates the parabola through y at x = -1, 0 and 1.
+func interpolate3(y [3]float64, x float64) float64 {
+	return y[1] + x*(y[2]-y[0])/2 + x*x*(y[0]-2*y[1]+y[2])/2
+}
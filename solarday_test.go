@@ -0,0 +1,96 @@
+// solarday_test.go
+
+package suntime
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSolarDay(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+	day := NewSolarDay(julianDay)
+
+	tests := []struct {
+		name   string
+		day    func(Location) (time.Time, error)
+		direct func(float64) (time.Time, error)
+	}{
+		{"Sunrise", day.Sunrise, testLocation.Sunrise},
+		{"Sunset", day.Sunset, testLocation.Sunset},
+		{"CivilTwilightSunrise", day.CivilTwilightSunrise, testLocation.CivilTwilightSunrise},
+		{"CivilTwilightSunset", day.CivilTwilightSunset, testLocation.CivilTwilightSunset},
+		{"NauticalTwilightSunrise", day.NauticalTwilightSunrise,
+			testLocation.NauticalTwilightSunrise},
+		{"NauticalTwilightSunset", day.NauticalTwilightSunset,
+			testLocation.NauticalTwilightSunset},
+		{"AstronomicalTwilightSunrise", day.AstronomicalTwilightSunrise,
+			testLocation.AstronomicalTwilightSunrise},
+		{"AstronomicalTwilightSunset", day.AstronomicalTwilightSunset,
+			testLocation.AstronomicalTwilightSunset},
+	}
+	for _, tt := range tests {
+		result, err := tt.day(testLocation)
+		if err != nil {
+			t.Errorf("SolarDay.%s() error = %v", tt.name, err)
+		}
+		expected, _ := tt.direct(julianDay)
+		if diff := result.Sub(expected); diff < -time.Second || diff > time.Second {
+			t.Errorf("SolarDay.%s() = %v, want %v", tt.name, result, expected)
+		}
+	}
+
+	noon := day.SolarNoon(testLocation)
+	expected := testLocation.SolarNoon(julianDay)
+	if diff := noon.Sub(expected); diff < -time.Second || diff > time.Second {
+		t.Errorf("SolarDay.SolarNoon() = %v, want %v", noon, expected)
+	}
+
+	if _, err := day.Sunrise(Location{Latitude: 80}); err != ErrSunAlwaysDown {
+		t.Errorf("SolarDay.Sunrise() error = %v, want %v", err, ErrSunAlwaysDown)
+	}
+}
+
+func BenchmarkSolarDay(b *testing.B) {
+	julianDay := ToJulianDay(testDate)
+	for i := 0; i < b.N; i++ {
+		day := NewSolarDay(julianDay)
+		day.AstronomicalTwilightSunrise(testLocation)
+		day.NauticalTwilightSunrise(testLocation)
+		day.CivilTwilightSunrise(testLocation)
+		day.Sunrise(testLocation)
+		day.SolarNoon(testLocation)
+		day.Sunset(testLocation)
+		day.CivilTwilightSunset(testLocation)
+		day.NauticalTwilightSunset(testLocation)
+		day.AstronomicalTwilightSunset(testLocation)
+	}
+}
+
+func BenchmarkSolarDayIndependent(b *testing.B) {
+	julianDay := ToJulianDay(testDate)
+	for i := 0; i < b.N; i++ {
+		testLocation.AstronomicalTwilightSunrise(julianDay)
+		testLocation.NauticalTwilightSunrise(julianDay)
+		testLocation.CivilTwilightSunrise(julianDay)
+		testLocation.Sunrise(julianDay)
+		testLocation.SolarNoon(julianDay)
+		testLocation.Sunset(julianDay)
+		testLocation.CivilTwilightSunset(julianDay)
+		testLocation.NauticalTwilightSunset(julianDay)
+		testLocation.AstronomicalTwilightSunset(julianDay)
+	}
+}
+
+func TestSolarDayInvalidJulianDay(t *testing.T) {
+	day := NewSolarDay(-1e9)
+	if result, err := day.Sunrise(testLocation); !result.IsZero() ||
+		!errors.Is(err, ErrInvalidJulianDay) {
+		t.Errorf("SolarDay.Sunrise() = %v, %v, want zero time, %v", result, err,
+			ErrInvalidJulianDay)
+	}
+	if result := day.SolarNoon(testLocation); !result.IsZero() {
+		t.Errorf("SolarDay.SolarNoon() = %v, want zero time", result)
+	}
+}
@@ -0,0 +1,114 @@
+// twilight.go
+
+package suntime
+
+import (
+	"errors"
+	"time"
+)
+
+// CivilTwilightMorningDuration calculates how long morning civil twilight lasts, from
+// civil dawn until sunrise. See twilightDuration for days on which it never ends.
+func CivilTwilightMorningDuration(julianDay, longitude, latitude float64) (time.Duration, error) {
+	return twilightDuration(julianDay, longitude, latitude, ZenithCivil, ZenithOfficial, true)
+}
+
+// CivilTwilightEveningDuration calculates how long evening civil twilight lasts, from
+// sunset until civil dusk.
+func CivilTwilightEveningDuration(julianDay, longitude, latitude float64) (time.Duration, error) {
+	return twilightDuration(julianDay, longitude, latitude, ZenithCivil, ZenithOfficial, false)
+}
+
+// NauticalTwilightMorningDuration calculates how long morning nautical twilight lasts,
+// from nautical dawn until civil dawn.
+func NauticalTwilightMorningDuration(
+	julianDay, longitude, latitude float64,
+) (time.Duration, error) {
+	return twilightDuration(julianDay, longitude, latitude, ZenithNautical, ZenithCivil, true)
+}
+
+// NauticalTwilightEveningDuration calculates how long evening nautical twilight lasts,
+// from civil dusk until nautical dusk.
+func NauticalTwilightEveningDuration(
+	julianDay, longitude, latitude float64,
+) (time.Duration, error) {
+	return twilightDuration(julianDay, longitude, latitude, ZenithNautical, ZenithCivil, false)
+}
+
+// AstronomicalTwilightMorningDuration calculates how long morning astronomical twilight
+// lasts, from astronomical dawn until nautical dawn.
+func AstronomicalTwilightMorningDuration(
+	julianDay, longitude, latitude float64,
+) (time.Duration, error) {
+	return twilightDuration(julianDay, longitude, latitude, ZenithAstronomical, ZenithNautical, true)
+}
+
+// AstronomicalTwilightEveningDuration calculates how long evening astronomical twilight
+// lasts, from nautical dusk until astronomical dusk.
+func AstronomicalTwilightEveningDuration(
+	julianDay, longitude, latitude float64,
+) (time.Duration, error) {
+	return twilightDuration(julianDay, longitude, latitude, ZenithAstronomical, ZenithNautical, false)
+}
+
+// twilightDuration returns the time the sun spends between the zenith angles low (the
+// darker limit) and high on one side of solar noon.
+//
+// Near the poles a phase may not have both limits. If the sun enters the phase but never
+// climbs out of it, the phase lasts until solar noon and ErrSunAlwaysDown is returned
+// with that duration; if the sun never sinks below the phase, it lasts from solar
+// midnight and ErrSunAlwaysUp is returned with that duration. A phase the sun never
+// reaches at all returns 0 with the corresponding error. Any other error, such as
+// ErrInvalidJulianDay, is returned with 0.
+func twilightDuration(
+	julianDay, longitude, latitude, low, high float64, isMorning bool,
+) (time.Duration, error) {
+	dark, darkErr := SunAtAngle(julianDay, longitude, latitude, low, isMorning)
+	light, lightErr := SunAtAngle(julianDay, longitude, latitude, high, isMorning)
+	for _, err := range []error{darkErr, lightErr} {
+		if err != nil && !isPolar(err) {
+			return 0, err
+		}
+	}
+	noon := SolarNoon(julianDay, longitude, latitude)
+
+	switch {
+	case errors.Is(darkErr, ErrSunAlwaysDown) || errors.Is(lightErr, ErrSunAlwaysUp):
+		// Dark all day, or light all night: the phase never happens
+		if darkErr != nil {
+			return 0, darkErr
+		}
+		return 0, lightErr
+	case darkErr == nil && lightErr == nil:
+		if isMorning {
+			return light.Sub(dark), nil
+		}
+		return dark.Sub(light), nil
+	case darkErr == nil:
+		// The sun never climbs above high: the phase runs into solar noon
+		if isMorning {
+			return noon.Sub(dark), ErrSunAlwaysDown
+		}
+		return dark.Sub(noon), ErrSunAlwaysDown
+	default:
+		// The sun never sinks below low: the phase runs from solar midnight
+		if isMorning {
+			return light.Sub(noon.Add(-12 * time.Hour)), ErrSunAlwaysUp
+		}
+		return noon.Add(12 * time.Hour).Sub(light), ErrSunAlwaysUp
+	}
+}
+
+// HasAstronomicalNight reports whether the sun sinks below 18° under the horizon at some
+// point of the day, giving a fully dark sky. It is false through the "white nights" of
+// high-latitude summers, well beyond the midnight sun, and true throughout polar night.
+// It is false for an out-of-range Julian day.
+func HasAstronomicalNight(julianDay, longitude, latitude float64) bool {
+	_, err := SunAtAngle(julianDay, longitude, latitude, ZenithAstronomical, false)
+	return err == nil || errors.Is(err, ErrSunAlwaysDown)
+}
+
+// isPolar reports whether err is ErrSunAlwaysUp or ErrSunAlwaysDown.
+func isPolar(err error) bool {
+	return errors.Is(err, ErrSunAlwaysUp) || errors.Is(err, ErrSunAlwaysDown)
+}
@@ -0,0 +1,121 @@
+// twilight_test.go
+
+package suntime
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTwilightDuration(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	result, err := CivilTwilightMorningDuration(julianDay, testLongitude, testLatitude)
+	if err != nil {
+		t.Errorf("CivilTwilightMorningDuration() error = %v", err)
+	}
+	expected := Sunrise(julianDay, testLongitude, testLatitude).Sub(
+		CivilTwilightSunrise(julianDay, testLongitude, testLatitude),
+	)
+	if result != expected {
+		t.Errorf("CivilTwilightMorningDuration() = %v, want %v", result, expected)
+	}
+}
+
+func TestTwilightDurationEquinox(t *testing.T) {
+	// Near the equinox the declination hardly changes over the day, so each
+	// morning phase lasts about as long as its evening counterpart.
+	julianDay := JulianDayForDate(2025, 3, 20)
+
+	tests := []struct {
+		name             string
+		morning, evening func(float64, float64, float64) (time.Duration, error)
+	}{
+		{"Civil", CivilTwilightMorningDuration, CivilTwilightEveningDuration},
+		{"Nautical", NauticalTwilightMorningDuration, NauticalTwilightEveningDuration},
+		{"Astronomical", AstronomicalTwilightMorningDuration, AstronomicalTwilightEveningDuration},
+	}
+	for _, tt := range tests {
+		morning, err := tt.morning(julianDay, testLongitude, testLatitude)
+		if err != nil {
+			t.Errorf("%sTwilightMorningDuration() error = %v", tt.name, err)
+		}
+		evening, err := tt.evening(julianDay, testLongitude, testLatitude)
+		if err != nil {
+			t.Errorf("%sTwilightEveningDuration() error = %v", tt.name, err)
+		}
+		if morning < 25*time.Minute || morning > 35*time.Minute {
+			t.Errorf("%sTwilightMorningDuration() = %v, want about 30m", tt.name, morning)
+		}
+		if diff := morning - evening; diff < -5*time.Second || diff > 5*time.Second {
+			t.Errorf("%s twilight morning = %v, evening = %v, want nearly equal",
+				tt.name, morning, evening)
+		}
+	}
+}
+
+func TestTwilightDurationPolar(t *testing.T) {
+	julianDay := ToJulianDay(testDate)
+
+	// At 70° N in January civil twilight never reaches sunrise and runs into noon.
+	result, err := CivilTwilightMorningDuration(julianDay, 0, 70)
+	if err != ErrSunAlwaysDown {
+		t.Errorf("CivilTwilightMorningDuration() error = %v, want %v", err, ErrSunAlwaysDown)
+	}
+	expected := SolarNoon(julianDay, 0, 70).Sub(CivilTwilightSunrise(julianDay, 0, 70))
+	if result != expected {
+		t.Errorf("CivilTwilightMorningDuration() = %v, want %v", result, expected)
+	}
+
+	// At 89° N it is dark all day.
+	if result, err := CivilTwilightMorningDuration(julianDay, 0, 89); result != 0 ||
+		err != ErrSunAlwaysDown {
+		t.Errorf("CivilTwilightMorningDuration() = %v, %v, want 0, %v", result, err,
+			ErrSunAlwaysDown)
+	}
+
+	// At 50° S in January the sun never sinks to -18°, so astronomical twilight runs
+	// from solar midnight until nautical dawn.
+	result, err = AstronomicalTwilightMorningDuration(julianDay, 0, -50)
+	if err != ErrSunAlwaysUp || result <= 0 {
+		t.Errorf("AstronomicalTwilightMorningDuration() = %v, %v, want positive, %v",
+			result, err, ErrSunAlwaysUp)
+	}
+}
+
+func TestHasAstronomicalNight(t *testing.T) {
+	june := ToJulianDay(time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC))
+	december := ToJulianDay(time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name      string
+		julianDay float64
+		latitude  float64
+		want      bool
+	}{
+		// At 55° N the June sun bottoms out near -11.5°
+		{"55° N in June", june, 55, false},
+		{"Flint Hill in June", june, testLatitude, true},
+		{"55° N in December", december, 55, true},
+		{"80° N polar night", december, 80, true},
+	}
+	for _, tt := range tests {
+		if result := HasAstronomicalNight(tt.julianDay, 0, tt.latitude); result != tt.want {
+			t.Errorf("HasAstronomicalNight(%s) = %v, want %v", tt.name, result, tt.want)
+		}
+	}
+}
+
+func TestTwilightDurationInvalidJulianDay(t *testing.T) {
+	result, err := CivilTwilightMorningDuration(-1, testLongitude, testLatitude)
+	if result != 0 || !errors.Is(err, ErrInvalidJulianDay) {
+		t.Errorf(
+			"CivilTwilightMorningDuration(-1) = %v, %v, want 0, %v", result, err,
+			ErrInvalidJulianDay,
+		)
+	}
+	if HasAstronomicalNight(-1, testLongitude, testLatitude) {
+		t.Errorf("HasAstronomicalNight(-1) = true, want false")
+	}
+}